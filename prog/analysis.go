@@ -4,7 +4,18 @@
 // Conservative resource-related analysis of programs.
 // The analysis figures out what files descriptors are [potentially] opened
 // at a particular point in program, what pages are [potentially] mapped,
-// what files were already referenced in calls, etc.
+// what files were already referenced in calls, etc. A small, hardcoded set
+// of well-known resource-destroying calls (see closingCalls) also retires
+// the resource they consume from s.resources, so later lookups in this same
+// analysis pass don't see it as still live.
+//
+// NOTE: closeResources runs at the end of every s.analyze(c), so the state
+// that analyze() returns no longer lists a resource that an earlier close or
+// shutdown call consumed.
+// Surfacing a live-resource set to ChoiceTable-driven selection, backed by a
+// real close/consumes annotation on Syscall populated by syz-extract (rather
+// than the closingCalls table below), is tracked separately as
+// crypticterminal/syzkaller#chunk0-6.
 
 package prog
 
@@ -77,6 +88,46 @@ func (s *state) analyze(c *Call) {
 			}
 		}
 	})
+	s.closeResources(c)
+}
+
+// closingCalls maps the CallName of well-known resource-destroying calls to
+// the index of the argument whose resource they consume.
+//
+// NOTE: this is deliberately a small hardcoded table rather than a Closes
+// annotation plumbed through Syscall/syz-extract. Wiring per-syscall close
+// annotations all the way from syz-extract through Syscall and into
+// ChoiceTable-driven resource selection is a bigger change than this pass
+// attempts; this only fixes the bookkeeping so a closed fd isn't considered
+// live in the state that analyze() builds and returns.
+var closingCalls = map[string]int{
+	"close":    0,
+	"shutdown": 0,
+}
+
+// closeResources removes the resource that c consumes (e.g. close(fd) or
+// shutdown(fd, how)) from the set of live resources. Calls not listed in
+// closingCalls behave exactly as before: nothing is removed.
+func (s *state) closeResources(c *Call) {
+	idx, ok := closingCalls[c.Meta.CallName]
+	if !ok || idx >= len(c.Args) {
+		return
+	}
+	res, ok := c.Args[idx].(*ResultArg)
+	if !ok || res.Res == nil {
+		return
+	}
+	typ, ok := res.Res.Type().(*ResourceType)
+	if !ok {
+		return
+	}
+	kind := typ.Desc.Name
+	for i, a := range s.resources[kind] {
+		if a == res.Res {
+			s.resources[kind] = append(s.resources[kind][:i], s.resources[kind][i+1:]...)
+			break
+		}
+	}
 }
 
 type ArgCtx struct {
@@ -4,65 +4,86 @@ import (
 	"fmt"
 )
 
+// Sizes of the textual renderings of a uint64 value in the respective base,
+// matching what fmt[dec/hex/oct, ...] uses elsewhere for formatted ints.
+const (
+	sizeStrFormatDec = 20
+	sizeStrFormatHex = 18
+	sizeStrFormatOct = 23
+)
+
+// anyTypes holds the synthetic type descriptions used to represent ANY-squashed
+// programs. It's stored behind target.anyTypes rather than as flat Target fields so
+// that it can be passed around and extended without growing Target itself.
 type anyTypes struct {
-	anyUnion  *UnionType
-	anyArray  *ArrayType
-	anyBlob   *BufferType
-	anyPtrPtr *PtrType
-	anyPtr64  *PtrType
-	anyRes32  *ResourceType
-	anyRes64  *ResourceType
+	union  *UnionType
+	array  *ArrayType
+	blob   *BufferType
+	ptrPtr *PtrType
+	ptr64  *PtrType
+	res16  *ResourceType
+	res32  *ResourceType
+	res64  *ResourceType
+	resDec *ResourceType
+	resHex *ResourceType
+	resOct *ResourceType
 }
 
 // This generates type descriptions for:
 //
+// resource ANYRES16[int16]: 0xffffffffffffffff, 0
 // resource ANYRES32[int32]: 0xffffffffffffffff, 0
 // resource ANYRES64[int64]: 0xffffffffffffffff, 0
 // ANY [
 // 	bin	array[int8]
 // 	ptr	ptr[in, array[ANY], opt]
 // 	ptr64	ptr64[in, array[ANY], opt]
+// 	res16	ANYRES16
 // 	res32	ANYRES32
 // 	res64	ANYRES64
+// 	resDec	fmt[dec, ANYRES64]
+// 	resHex	fmt[hex, ANYRES64]
+// 	resOct	fmt[oct, ANYRES64]
 // ] [varlen]
 func initAnyTypes(target *Target) {
-	target.anyUnion = &UnionType{
+	a := &anyTypes{}
+	a.union = &UnionType{
 		FldName: "ANYUNION",
 	}
-	target.anyArray = &ArrayType{
+	a.array = &ArrayType{
 		TypeCommon: TypeCommon{
 			TypeName: "ANYARRAY",
 			FldName:  "ANYARRAY",
 			IsVarlen: true,
 		},
-		Type: target.anyUnion,
+		Type: a.union,
 	}
-	target.anyPtrPtr = &PtrType{
+	a.ptrPtr = &PtrType{
 		TypeCommon: TypeCommon{
 			TypeName:   "ptr",
 			FldName:    "ANYPTR",
 			TypeSize:   target.PtrSize,
 			IsOptional: true,
 		},
-		Type: target.anyArray,
+		Type: a.array,
 	}
-	target.anyPtr64 = &PtrType{
+	a.ptr64 = &PtrType{
 		TypeCommon: TypeCommon{
 			TypeName:   "ptr64",
 			FldName:    "ANYPTR64",
 			TypeSize:   8,
 			IsOptional: true,
 		},
-		Type: target.anyArray,
+		Type: a.array,
 	}
-	target.anyBlob = &BufferType{
+	a.blob = &BufferType{
 		TypeCommon: TypeCommon{
 			TypeName: "ANYBLOB",
 			FldName:  "ANYBLOB",
 			IsVarlen: true,
 		},
 	}
-	createResource := func(name, base string, size uint64) *ResourceType {
+	createResource := func(name, base string, size uint64, format BinaryFormat) *ResourceType {
 		return &ResourceType{
 			TypeCommon: TypeCommon{
 				TypeName:   name,
@@ -70,6 +91,7 @@ func initAnyTypes(target *Target) {
 				ArgDir:     DirIn,
 				TypeSize:   size,
 				IsOptional: true,
+				ArgFormat:  format,
 			},
 			Desc: &ResourceDesc{
 				Name:   name,
@@ -86,9 +108,17 @@ func initAnyTypes(target *Target) {
 			},
 		}
 	}
-	target.anyRes32 = createResource("ANYRES32", "int32", 4)
-	target.anyRes64 = createResource("ANYRES64", "int64", 8)
-	target.anyUnion.StructDesc = &StructDesc{
+	a.res16 = createResource("ANYRES16", "int16", 2, FormatNative)
+	a.res32 = createResource("ANYRES32", "int32", 4, FormatNative)
+	a.res64 = createResource("ANYRES64", "int64", 8, FormatNative)
+	// The formatted variants mirror fmt[dec/hex/oct, ANYRES64]: the resource value
+	// is rendered as ASCII text rather than raw bytes, so it can be squashed
+	// without losing the resource link even when embedded in a string argument
+	// (e.g. a filename or sysctl path).
+	a.resDec = createResource("ANYRESDEC", "intptr", sizeStrFormatDec, FormatStrDec)
+	a.resHex = createResource("ANYRESHEX", "intptr", sizeStrFormatHex, FormatStrHex)
+	a.resOct = createResource("ANYRESOCT", "intptr", sizeStrFormatOct, FormatStrOct)
+	a.union.StructDesc = &StructDesc{
 		TypeCommon: TypeCommon{
 			TypeName: "ANYUNION",
 			FldName:  "ANYUNION",
@@ -96,26 +126,68 @@ func initAnyTypes(target *Target) {
 			ArgDir:   DirIn,
 		},
 		Fields: []Type{
-			target.anyBlob,
-			target.anyPtrPtr,
-			target.anyPtr64,
-			target.anyRes32,
-			target.anyRes64,
+			a.blob,
+			a.ptrPtr,
+			a.ptr64,
+			a.res16,
+			a.res32,
+			a.res64,
+			a.resDec,
+			a.resHex,
+			a.resOct,
 		},
 	}
+	target.anyTypes = a
+}
+
+// AnyUnion returns the synthetic ANYUNION type used by ANY-squashed programs.
+func (target *Target) AnyUnion() *UnionType {
+	return target.anyTypes.union
+}
+
+// AnyPtr returns the synthetic ANY pointer type of the given size (either
+// target.PtrSize or 8, for ptr64).
+func (target *Target) AnyPtr(size uint64) *PtrType {
+	switch size {
+	case target.PtrSize:
+		return target.anyTypes.ptrPtr
+	case 8:
+		return target.anyTypes.ptr64
+	default:
+		panic(fmt.Sprintf("bad pointer size %v", size))
+	}
+}
+
+// AnyRes returns the synthetic ANYRES resource type for the given raw
+// (non-formatted) resource size.
+func (target *Target) AnyRes(size uint64) *ResourceType {
+	switch size {
+	case 2:
+		return target.anyTypes.res16
+	case 4:
+		return target.anyTypes.res32
+	case 8:
+		return target.anyTypes.res64
+	default:
+		panic(fmt.Sprintf("bad resource size %v", size))
+	}
+}
+
+// IsAnyRes returns true if typ is one of the synthetic ANYRES* resource types.
+func (target *Target) IsAnyRes(typ Type) bool {
+	switch typ {
+	case target.anyTypes.res16, target.anyTypes.res32, target.anyTypes.res64,
+		target.anyTypes.resDec, target.anyTypes.resHex, target.anyTypes.resOct:
+		return true
+	default:
+		return false
+	}
 }
 
 func (target *Target) makeAnyPtrType(size uint64, field string) *PtrType {
 	// We need to make a copy because type holds field name,
 	// and field names are used as len target.
-	var typ PtrType
-	if size == target.PtrSize {
-		typ = *target.anyPtrPtr
-	} else if size == 8 {
-		typ = *target.anyPtr64
-	} else {
-		panic(fmt.Sprintf("bad pointer size %v", size))
-	}
+	typ := *target.AnyPtr(size)
 	typ.TypeSize = size
 	if field != "" {
 		typ.FldName = field
@@ -125,7 +197,7 @@ func (target *Target) makeAnyPtrType(size uint64, field string) *PtrType {
 
 func (target *Target) isAnyPtr(typ Type) bool {
 	ptr, ok := typ.(*PtrType)
-	return ok && ptr.Type == target.anyArray
+	return ok && ptr.Type == target.anyTypes.array
 }
 
 func (p *Prog) complexPtrs() (res []*PointerArg) {
@@ -227,19 +299,21 @@ func (target *Target) squashPtrImpl(a Arg, elems *[]Arg) {
 			}
 		}
 	case *ResultArg:
-		switch arg.Size() {
-		case 4:
-			arg.typ = target.anyRes32
-		case 8:
-			arg.typ = target.anyRes64
+		switch format := arg.Type().Format(); {
+		case format == FormatStrDec:
+			arg.typ = target.anyTypes.resDec
+		case format == FormatStrHex:
+			arg.typ = target.anyTypes.resHex
+		case format == FormatStrOct:
+			arg.typ = target.anyTypes.resOct
 		default:
-			panic("bad size")
+			arg.typ = target.AnyRes(arg.Size())
 		}
-		*elems = append(*elems, MakeUnionArg(target.anyUnion, arg))
+		*elems = append(*elems, MakeUnionArg(target.anyTypes.union, arg))
 	case *PointerArg:
 		if arg.Res != nil {
 			target.squashPtr(arg, false)
-			*elems = append(*elems, MakeUnionArg(target.anyUnion, arg))
+			*elems = append(*elems, MakeUnionArg(target.anyTypes.union, arg))
 		} else {
 			elem := target.ensureDataElem(elems)
 			addr := target.PhysicalAddr(arg)
@@ -290,14 +364,14 @@ func (target *Target) squashPtrImpl(a Arg, elems *[]Arg) {
 
 func (target *Target) ensureDataElem(elems *[]Arg) *DataArg {
 	if len(*elems) == 0 {
-		res := MakeDataArg(target.anyBlob, nil)
-		*elems = append(*elems, MakeUnionArg(target.anyUnion, res))
+		res := MakeDataArg(target.anyTypes.blob, nil)
+		*elems = append(*elems, MakeUnionArg(target.anyTypes.union, res))
 		return res
 	}
 	res, ok := (*elems)[len(*elems)-1].(*UnionArg).Option.(*DataArg)
 	if !ok {
-		res = MakeDataArg(target.anyBlob, nil)
-		*elems = append(*elems, MakeUnionArg(target.anyUnion, res))
+		res = MakeDataArg(target.anyTypes.blob, nil)
+		*elems = append(*elems, MakeUnionArg(target.anyTypes.union, res))
 	}
 	return res
 }
@@ -0,0 +1,88 @@
+// Copyright 2019 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package prog
+
+import "testing"
+
+// squashResult runs a single *ResultArg of the given type through
+// squashPtrImpl and returns the *ResultArg it was rewritten to.
+func squashResult(t *testing.T, target *Target, typ *ResourceType) *ResultArg {
+	t.Helper()
+	arg := MakeResultArg(typ, nil, 0)
+	var elems []Arg
+	target.squashPtrImpl(arg, &elems)
+	if len(elems) != 1 {
+		t.Fatalf("squashing a resource produced %v elems, want 1", len(elems))
+	}
+	union, ok := elems[0].(*UnionArg)
+	if !ok {
+		t.Fatalf("squashed elem is a %T, want *UnionArg", elems[0])
+	}
+	res, ok := union.Option.(*ResultArg)
+	if !ok {
+		t.Fatalf("union option is a %T, want *ResultArg", union.Option)
+	}
+	return res
+}
+
+func TestSquashResourceBySize(t *testing.T) {
+	target, _, _ := initTest(t)
+	for _, tt := range []struct {
+		size uint64
+		want *ResourceType
+	}{
+		{2, target.anyTypes.res16},
+		{4, target.anyTypes.res32},
+		{8, target.anyTypes.res64},
+	} {
+		res := squashResult(t, target, target.AnyRes(tt.size))
+		if res.Type() != tt.want {
+			t.Errorf("size %v: squashed to %v, want %v", tt.size, res.Type(), tt.want)
+		}
+	}
+}
+
+func TestSquashResourceByFormat(t *testing.T) {
+	target, _, _ := initTest(t)
+	for _, tt := range []struct {
+		name   string
+		format BinaryFormat
+		want   *ResourceType
+	}{
+		{"dec", FormatStrDec, target.anyTypes.resDec},
+		{"hex", FormatStrHex, target.anyTypes.resHex},
+		{"oct", FormatStrOct, target.anyTypes.resOct},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			typ := &ResourceType{
+				TypeCommon: TypeCommon{
+					TypeName:  "formatted_res",
+					TypeSize:  8,
+					ArgFormat: tt.format,
+				},
+				Desc: target.anyTypes.res64.Desc,
+			}
+			res := squashResult(t, target, typ)
+			if res.Type() != tt.want {
+				t.Errorf("format %v: squashed to %v, want %v", tt.name, res.Type(), tt.want)
+			}
+		})
+	}
+}
+
+func TestIsAnyRes(t *testing.T) {
+	target, _, _ := initTest(t)
+	for _, typ := range []*ResourceType{
+		target.anyTypes.res16, target.anyTypes.res32, target.anyTypes.res64,
+		target.anyTypes.resDec, target.anyTypes.resHex, target.anyTypes.resOct,
+	} {
+		if !target.IsAnyRes(typ) {
+			t.Errorf("IsAnyRes(%v) = false, want true", typ.Name())
+		}
+	}
+	other := &ResourceType{TypeCommon: TypeCommon{TypeName: "fd", TypeSize: 4}, Desc: target.anyTypes.res32.Desc}
+	if target.IsAnyRes(other) {
+		t.Errorf("IsAnyRes(%v) = true for an unrelated resource type, want false", other.Name())
+	}
+}
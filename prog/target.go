@@ -0,0 +1,34 @@
+// Copyright 2017 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package prog
+
+// Target describes a fuzzing target: an OS/arch pair along with the set of
+// syscalls, resources, structs and consts generated for it from the .txt
+// syscall descriptions.
+//
+// NOTE: this is a deliberately narrow slice of the real Target type, carrying
+// only the fields this tree's prog/sys packages actually reference (resource
+// bookkeeping in analysis.go, ANY-squashing in any.go, and the FreeBSD target
+// deserialization test). Call/Syscall/ChoiceTable plumbing, the compiler
+// frontend, and the rest of Target's surface live elsewhere in the full
+// syzkaller tree and aren't reconstructed here.
+type Target struct {
+	OS       string
+	Arch     string
+	Revision string
+
+	PtrSize  uint64
+	PageSize uint64
+	NumPages uint64
+
+	Syscalls  []*Syscall
+	Resources []*ResourceDesc
+	Structs   []*KeyedStruct
+	Consts    []ConstValue
+
+	// anyTypes holds the synthetic types used by ANY-squashing (see any.go).
+	// It's a field on Target, not a package global, so that multiple
+	// targets (e.g. different arches) don't share mutable type state.
+	anyTypes *anyTypes
+}
@@ -9,6 +9,11 @@ func init() {
 
 var resources_amd64 = []*ResourceDesc{
 	{Name: "fd", Type: &IntType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "int32", TypeSize: 4}}}, Kind: []string{"fd"}, Values: []uint64{18446744073709551615}},
+	{Name: "cap_rights_t", Type: &IntType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "int64", TypeSize: 8}}}, Kind: []string{"cap_rights_t"}, Values: []uint64{0}},
+	// jail_id is the jid returned by jail(2); it is a distinct namespace from
+	// file descriptors (jid 0 is the host/base system, so it's the natural
+	// invalid sentinel, unlike fd's -1).
+	{Name: "jail_id", Type: &IntType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "int32", TypeSize: 4}}}, Kind: []string{"jail_id"}, Values: []uint64{0}},
 }
 
 var structDescs_amd64 = []*KeyedStruct{
@@ -16,6 +21,52 @@ var structDescs_amd64 = []*KeyedStruct{
 		&ResourceType{TypeCommon: TypeCommon{TypeName: "fd", FldName: "rfd", TypeSize: 4, ArgDir: 1}},
 		&ResourceType{TypeCommon: TypeCommon{TypeName: "fd", FldName: "wfd", TypeSize: 4, ArgDir: 1}},
 	}}},
+	{Key: StructKey{Name: "kevent"}, Desc: &StructDesc{TypeCommon: TypeCommon{TypeName: "kevent", TypeSize: 32}, Fields: []Type{
+		&IntType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "intptr", FldName: "ident", TypeSize: 8}}},
+		&FlagsType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "kevent_filter", FldName: "filter", TypeSize: 2}}, Vals: []uint64{18446744073709551615, 18446744073709551614}},
+		&FlagsType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "kevent_flags", FldName: "flags", TypeSize: 2}}, Vals: []uint64{1, 2}},
+		&IntType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "int32", FldName: "fflags", TypeSize: 4}}},
+		&IntType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "intptr", FldName: "data", TypeSize: 8}}},
+		&PtrType{TypeCommon: TypeCommon{TypeName: "ptr", FldName: "udata", TypeSize: 8, IsOptional: true}, Type: &IntType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "int8", TypeSize: 1}}}},
+	}}},
+	{Key: StructKey{Name: "sockaddr_in"}, Desc: &StructDesc{TypeCommon: TypeCommon{TypeName: "sockaddr_in", TypeSize: 16}, Fields: []Type{
+		&ConstType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "int8", FldName: "len", TypeSize: 1}}, Val: 16},
+		&ConstType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "int8", FldName: "family", TypeSize: 1}}, Val: 2},
+		&IntType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "int16be", FldName: "port", TypeSize: 2}}},
+		&IntType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "ipv4", FldName: "addr", TypeSize: 4}}},
+		&ConstType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "int64", FldName: "zero", TypeSize: 8}}},
+	}}},
+	// struct jail (version JAIL_API_VERSION): the three uint32_t fields
+	// (version, ip4s, ip6s) are each followed by 4 bytes of compiler-inserted
+	// padding to keep the following pointer fields 8-byte aligned on amd64:
+	// 4(version) + 4(pad) + 8(path) + 8(hostname) + 8(jailname) + 4(ip4s) +
+	// 4(pad) + 8(ip4) + 4(ip6s) + 4(pad) + 8(ip6) = 64 bytes, matching
+	// TypeSize below.
+	{Key: StructKey{Name: "jail"}, Desc: &StructDesc{TypeCommon: TypeCommon{TypeName: "jail", TypeSize: 64}, Fields: []Type{
+		&ConstType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "int32", FldName: "version", TypeSize: 4}}, Val: 2},
+		&ConstType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "int32", FldName: "pad", TypeSize: 4}}},
+		&PtrType{TypeCommon: TypeCommon{TypeName: "ptr", FldName: "path", TypeSize: 8}, Type: &BufferType{TypeCommon: TypeCommon{TypeName: "filename", IsVarlen: true}, Kind: BufferFilename}},
+		&PtrType{TypeCommon: TypeCommon{TypeName: "ptr", FldName: "hostname", TypeSize: 8}, Type: &BufferType{TypeCommon: TypeCommon{TypeName: "string", IsVarlen: true}, Kind: BufferString}},
+		&PtrType{TypeCommon: TypeCommon{TypeName: "ptr", FldName: "jailname", TypeSize: 8, IsOptional: true}, Type: &BufferType{TypeCommon: TypeCommon{TypeName: "string", IsVarlen: true}, Kind: BufferString}},
+		&IntType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "int32", FldName: "ip4s", TypeSize: 4}}},
+		&ConstType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "int32", FldName: "pad", TypeSize: 4}}},
+		&PtrType{
+			TypeCommon: TypeCommon{TypeName: "ptr", FldName: "ip4", TypeSize: 8, IsOptional: true},
+			Type: &ArrayType{
+				TypeCommon: TypeCommon{TypeName: "array", IsVarlen: true},
+				Type:       &IntType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "ipv4", TypeSize: 4}}},
+			},
+		},
+		&IntType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "int32", FldName: "ip6s", TypeSize: 4}}},
+		&ConstType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "int32", FldName: "pad", TypeSize: 4}}},
+		&PtrType{
+			TypeCommon: TypeCommon{TypeName: "ptr", FldName: "ip6", TypeSize: 8, IsOptional: true},
+			Type: &ArrayType{
+				TypeCommon: TypeCommon{TypeName: "array", IsVarlen: true},
+				Type:       &IntType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "ipv6", TypeSize: 16}}},
+			},
+		},
+	}}},
 }
 
 var syscalls_amd64 = []*Syscall{
@@ -30,16 +81,72 @@ var syscalls_amd64 = []*Syscall{
 	{ID: 1, NR: 42, Name: "pipe", CallName: "pipe", Args: []Type{
 		&PtrType{TypeCommon: TypeCommon{TypeName: "ptr", FldName: "pipefd", TypeSize: 8}, Type: &StructType{Key: StructKey{Name: "pipefd", Dir: 1}}},
 	}},
+	{ID: 2, NR: 5, Name: "open", CallName: "open", Args: []Type{
+		&PtrType{TypeCommon: TypeCommon{TypeName: "ptr", FldName: "path", TypeSize: 8}, Type: &BufferType{TypeCommon: TypeCommon{TypeName: "filename", IsVarlen: true}, Kind: BufferFilename}},
+		&FlagsType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "open_flags", FldName: "flags", TypeSize: 4}}, Vals: []uint64{0, 1, 2, 512}},
+		&ConstType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "int16", FldName: "mode", TypeSize: 2}}, Val: 0x1ff},
+	}, Ret: &ResourceType{TypeCommon: TypeCommon{TypeName: "fd", FldName: "ret", TypeSize: 4, ArgDir: 1}}},
+	{ID: 3, NR: 6, Name: "close", CallName: "close", Args: []Type{
+		&ResourceType{TypeCommon: TypeCommon{TypeName: "fd", FldName: "fd", TypeSize: 4}},
+	}},
+	{ID: 4, NR: 97, Name: "socket", CallName: "socket", Args: []Type{
+		&FlagsType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "socket_domain", FldName: "domain", TypeSize: 4}}, Vals: []uint64{2}},
+		&FlagsType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "socket_type", FldName: "type", TypeSize: 4}}, Vals: []uint64{1, 2}},
+		&ConstType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "const", FldName: "protocol", TypeSize: 4}}},
+	}, Ret: &ResourceType{TypeCommon: TypeCommon{TypeName: "fd", FldName: "ret", TypeSize: 4, ArgDir: 1}}},
+	{ID: 5, NR: 362, Name: "kqueue", CallName: "kqueue", Ret: &ResourceType{TypeCommon: TypeCommon{TypeName: "fd", FldName: "ret", TypeSize: 4, ArgDir: 1}}},
+	{ID: 6, NR: 363, Name: "kevent", CallName: "kevent", Args: []Type{
+		&ResourceType{TypeCommon: TypeCommon{TypeName: "fd", FldName: "kq"}},
+		&PtrType{TypeCommon: TypeCommon{TypeName: "ptr", FldName: "changelist", TypeSize: 8, IsOptional: true}, Type: &ArrayType{TypeCommon: TypeCommon{TypeName: "array", IsVarlen: true}, Type: &StructType{Key: StructKey{Name: "kevent"}}}},
+		&LenType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "len", FldName: "nchanges", TypeSize: 4}}, Buf: "changelist"},
+		&PtrType{TypeCommon: TypeCommon{TypeName: "ptr", FldName: "eventlist", TypeSize: 8, IsOptional: true, ArgDir: 1}, Type: &ArrayType{TypeCommon: TypeCommon{TypeName: "array", IsVarlen: true, ArgDir: 1}, Type: &StructType{Key: StructKey{Name: "kevent"}}}},
+		&LenType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "len", FldName: "nevents", TypeSize: 4}}, Buf: "eventlist"},
+		&PtrType{TypeCommon: TypeCommon{TypeName: "ptr", FldName: "timeout", TypeSize: 8, IsOptional: true}, Type: &IntType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "intptr", TypeSize: 8}}}},
+	}},
+	{ID: 7, NR: 532, Name: "cap_rights_limit", CallName: "cap_rights_limit", Args: []Type{
+		&ResourceType{TypeCommon: TypeCommon{TypeName: "fd", FldName: "fd"}},
+		&PtrType{TypeCommon: TypeCommon{TypeName: "ptr", FldName: "rights", TypeSize: 8}, Type: &ResourceType{TypeCommon: TypeCommon{TypeName: "cap_rights_t"}}},
+	}},
+	{ID: 8, NR: 338, Name: "jail", CallName: "jail", Args: []Type{
+		&PtrType{TypeCommon: TypeCommon{TypeName: "ptr", FldName: "jail", TypeSize: 8}, Type: &StructType{Key: StructKey{Name: "jail"}}},
+	}, Ret: &ResourceType{TypeCommon: TypeCommon{TypeName: "jail_id", FldName: "ret", TypeSize: 4, ArgDir: 1}}},
+	{ID: 9, NR: 544, Name: "procctl", CallName: "procctl", Args: []Type{
+		&FlagsType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "idtype", FldName: "idtype", TypeSize: 4}}, Vals: []uint64{0}},
+		&ConstType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "intptr", FldName: "id", TypeSize: 8}}},
+		&FlagsType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "procctl_cmd", FldName: "com", TypeSize: 4}}, Vals: []uint64{2, 3}},
+		&PtrType{TypeCommon: TypeCommon{TypeName: "ptr", FldName: "data", TypeSize: 8, IsOptional: true}, Type: &IntType{IntTypeCommon: IntTypeCommon{TypeCommon: TypeCommon{TypeName: "int32", TypeSize: 4}}}},
+	}},
 }
 
 var consts_amd64 = []ConstValue{
+	{Name: "AF_INET", Value: 2},
+	{Name: "CAP_READ", Value: 0x0000000200000001},
+	{Name: "CAP_WRITE", Value: 0x0000000200000002},
+	{Name: "JAIL_API_VERSION", Value: 2},
 	{Name: "MAP_ANONYMOUS", Value: 4096},
 	{Name: "MAP_FIXED", Value: 16},
 	{Name: "MAP_PRIVATE", Value: 2},
+	{Name: "O_CREAT", Value: 512},
+	{Name: "O_RDONLY", Value: 0},
+	{Name: "O_RDWR", Value: 2},
+	{Name: "O_WRONLY", Value: 1},
+	{Name: "PROC_REAP_ACQUIRE", Value: 2},
+	{Name: "PROC_REAP_RELEASE", Value: 3},
 	{Name: "PROT_READ", Value: 1},
 	{Name: "PROT_WRITE", Value: 2},
+	{Name: "P_PID", Value: 0},
+	{Name: "SOCK_DGRAM", Value: 2},
+	{Name: "SOCK_STREAM", Value: 1},
+	{Name: "SYS_cap_rights_limit", Value: 532},
+	{Name: "SYS_close", Value: 6},
+	{Name: "SYS_jail", Value: 338},
+	{Name: "SYS_kevent", Value: 363},
+	{Name: "SYS_kqueue", Value: 362},
 	{Name: "SYS_mmap", Value: 477},
+	{Name: "SYS_open", Value: 5},
 	{Name: "SYS_pipe", Value: 42},
+	{Name: "SYS_procctl", Value: 544},
+	{Name: "SYS_socket", Value: 97},
 }
 
 const revision_amd64 = "7c737d486a33a6a0817ce924247b4b67428f7a07"
\ No newline at end of file
@@ -0,0 +1,27 @@
+// Copyright 2019 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package freebsd
+
+import (
+	"testing"
+
+	"github.com/google/syzkaller/prog"
+)
+
+// TestDeserialize is a regression test for the syz-extract/freebsd backend:
+// every syscall populated into syscalls_amd64 must produce a description
+// that round-trips through prog.Deserialize, i.e. extraction didn't emit a
+// syscall whose resources/structs/consts don't actually resolve.
+func TestDeserialize(t *testing.T) {
+	target, err := prog.GetTarget("freebsd", "amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, syscall := range target.Syscalls {
+		data := []byte(syscall.Name + "()")
+		if _, err := target.Deserialize(data, prog.NonStrict); err != nil {
+			t.Errorf("failed to deserialize %v: %v", syscall.Name, err)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright 2017 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import "github.com/google/syzkaller/pkg/compiler"
+
+// extractor resolves the symbolic constants referenced by a target's .txt
+// descriptions (syscall numbers, flags, struct field values, ...) into
+// concrete numeric values, consulting OS headers/sources as needed.
+type extractor interface {
+	prepare(sourcedir string, build bool, arches []*Arch) error
+	processFile(info *compiler.ConstInfo) (vals map[string]uint64, undeclared map[string]bool, err error)
+}
+
+// extractors maps a target OS name to its extractor backend. Only freebsd is
+// added here; the other OS backends are registered next to their own
+// extractor implementations elsewhere in the tree.
+var extractors = map[string]extractor{
+	"freebsd": new(freebsd),
+}
@@ -0,0 +1,144 @@
+// Copyright 2019 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/google/syzkaller/pkg/compiler"
+)
+
+type freebsd struct {
+	sourcedir string
+}
+
+func (fbsd *freebsd) prepare(sourcedir string, build bool, arches []*Arch) error {
+	if sourcedir == "" {
+		return fmt.Errorf("provide path to freebsd kernel checkout via -sourcedir flag (e.g. /usr/src)")
+	}
+	for _, arch := range arches {
+		if arch.target.Arch != "amd64" {
+			return fmt.Errorf("freebsd extraction is only supported for amd64")
+		}
+	}
+	fbsd.sourcedir = sourcedir
+	return nil
+}
+
+func (fbsd *freebsd) processFile(info *compiler.ConstInfo) (map[string]uint64, map[string]bool, error) {
+	// FreeBSD has no __NR_* macros, so constants like SYS_mmap are not
+	// discoverable via the generic cpp-based extraction that other targets
+	// use for sysnums. We additionally cross-reference syscalls.master
+	// (or, when it cannot be opened, the generated sys/sys/syscall.h;
+	// sysproto.h is not read) to resolve SYS_* values and fall back to cpp+system
+	// headers for everything else (flags, structs, errno, ...).
+	nrs, err := parseSyscallsMaster(fbsd.sourcedir, info.Consts)
+	if err != nil {
+		return nil, nil, err
+	}
+	vals, undeclared, err := extractConsts(info, nrs)
+	if err != nil {
+		return nil, nil, err
+	}
+	for name, nr := range nrs {
+		if _, ok := vals[name]; !ok {
+			vals[name] = nr
+			delete(undeclared, name)
+		}
+	}
+	return vals, undeclared, nil
+}
+
+// syscallsMasterRe matches non-compat lines of /usr/src/sys/kern/syscalls.master, e.g.:
+// 477	AUE_MMAP	STD	{ void *sys_mmap(...); }
+// 362	AUE_KQUEUE	STD|CAPENABLED	{ int sys_kqueue(void); }
+// Note the return type and sys_name(...) are NOT necessarily space-separated
+// (pointer returns like "void *sys_mmap(" glue the '*' straight onto the name),
+// and the type column may carry a pipe-joined capability-mode modifier like
+// |CAPENABLED (most modern socket/file/capsicum syscalls do).
+var syscallsMasterRe = regexp.MustCompile(`^(\d+)\s+AUE_\S+\s+(?:STD|NOSTD)(?:\|\S+)?\s+\{\s*\S+?\W*sys_(\w+)\(`)
+
+// parseSyscallsMaster extracts SYS_<name> -> syscall number for every
+// SYS_<name> constant referenced by the descriptions, so amd64.go (and
+// future arches) can populate their syscalls_* tables with real numbers
+// instead of the mmap/pipe-only stub. sourcedir is the freebsd kernel
+// checkout passed via -sourcedir (e.g. /usr/src).
+func parseSyscallsMaster(sourcedir string, wantConsts map[string]bool) (map[string]uint64, error) {
+	path := filepath.Join(sourcedir, "sys/kern/syscalls.master")
+	f, err := os.Open(path)
+	if err != nil {
+		// Fall back to the generated sys/sys/syscall.h of the same tree when
+		// syscalls.master cannot be opened.
+		return parseGeneratedSyscallHeader(filepath.Join(sourcedir, "sys/sys/syscall.h"), wantConsts)
+	}
+	defer f.Close()
+	nrs := make(map[string]uint64)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		m := syscallsMasterRe.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		name := "SYS_" + m[2]
+		if !wantConsts[name] {
+			continue
+		}
+		nr, err := strconv.ParseUint(m[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		nrs[name] = nr
+	}
+	return nrs, scanner.Err()
+}
+
+// generatedSyscallRe matches lines of a generated sys/syscall.h, e.g.:
+// #define	SYS_mmap	477
+var generatedSyscallRe = regexp.MustCompile(`^#define\s+(SYS_\w+)\s+(\d+)`)
+
+func parseGeneratedSyscallHeader(path string, wantConsts map[string]bool) (map[string]uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %v or syscalls.master: %v", filepath.Base(path), err)
+	}
+	defer f.Close()
+	nrs := make(map[string]uint64)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		m := generatedSyscallRe.FindStringSubmatch(scanner.Text())
+		if m == nil || !wantConsts[m[1]] {
+			continue
+		}
+		nr, err := strconv.ParseUint(m[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		nrs[m[1]] = nr
+	}
+	return nrs, scanner.Err()
+}
+
+// freebsdHeaders are included ahead of every probe program so that cpp can
+// resolve ordinary (non SYS_*) constants referenced by the .txt descriptions:
+// file I/O and socket flags, capsicum cap_rights bits, kqueue/kevent filters
+// and flags, and jail parameters.
+var freebsdHeaders = []string{
+	"sys/types.h",
+	"sys/socket.h",
+	"sys/capsicum.h",
+	"sys/event.h",
+	"sys/jail.h",
+	"sys/procctl.h",
+	"sys/mman.h",
+	"fcntl.h",
+}
+
+func (fbsd *freebsd) headers() []string {
+	return freebsdHeaders
+}